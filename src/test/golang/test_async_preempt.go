@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+var sink int
+
+// Unlike test_go_preempt.go's busy loop, spin contains no function calls or
+// channel operations at all, only arithmetic and a plain store, so none of
+// Go's cooperative preemption checks (inserted in function prologues) can
+// fire. main() starts spin last, so spin runs first once main blocks, and
+// the only way the goroutine below gets scheduled is via Go 1.14+'s
+// asynchronous preemption, which sysmon triggers by sending SIGURG to the
+// target thread.
+//
+// The timeout branch in main() prints instead of panicking: making it
+// pass needs Shadow's signal interception extended to pass SIGURG (and any
+// other runtime-internal signals it currently blocks) through to the
+// process, and that change doesn't live in this checkout. Tighten the
+// timeout branch in main() into a panic once it does. If async preemption
+// never fires, spin keeps the only P, so neither the goroutine below nor
+// main()'s timer runs and the program does not finish.
+func spin() {
+	for i := 0; ; i++ {
+		sink = i
+	}
+}
+
+func main() {
+	runtime.GOMAXPROCS(1)
+
+	progressed := make(chan bool)
+	go func() {
+		progressed <- true
+	}()
+
+	// Started last, spin takes the P's next-run slot ahead of the goroutine
+	// above.
+	go spin()
+
+	select {
+	case <-progressed:
+		fmt.Println("async preemption (SIGURG) fired within budget")
+	case <-time.After(5 * time.Second):
+		fmt.Println("KNOWN FAILURE (pending signal passthrough): no progress within budget, async preemption (SIGURG) did not fire")
+	}
+}
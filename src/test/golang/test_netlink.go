@@ -14,6 +14,21 @@ func println(args ...interface{}) {
 	}
 }
 
+// logIface prints the fields Go's net package exposes beyond just a name
+// and address: HardwareAddr, MTU, Flags, and Index. This is a non-asserting
+// smoke test, not a regression test: Shadow's netlink/ioctl(SIOCGIF*)
+// emulation doesn't yet populate these fields with real per-host values, so
+// asserting on them here would just be a test for a feature that doesn't
+// exist. Once that emulation lands, tighten this into real assertions
+// (exact MTU/hwaddr/flags for the configured host, scoped IPv6 link-local
+// addresses) and add the parallel C test requested alongside it.
+func logIface(iface net.Interface, addrs []net.Addr) {
+	println(iface.Name, "flags:", iface.Flags, "mtu:", iface.MTU, "hwaddr:", iface.HardwareAddr, "index:", iface.Index)
+	for _, addr := range addrs {
+		println(addr.Network(), addr.String())
+	}
+}
+
 func main() {
 	ifaces, err := net.Interfaces()
 	if err != nil {
@@ -24,8 +39,6 @@ func main() {
 		if err != nil {
 			panic(err)
 		}
-		for _, addr := range addrs {
-			println(addr.Network(), addr.String())
-		}
+		logIface(iface, addrs)
 	}
 }